@@ -0,0 +1,235 @@
+package main
+
+import (
+	"math"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	cameraFOV  = 45.0
+	cameraNear = 0.1
+)
+
+// Camera is the pluggable camera interface every viewing mode implements.
+// Reframe repositions the camera to view the given scene bounds, using the
+// same center/size math initializeCamera used to use directly on the old
+// global camera state.
+type Camera interface {
+	View() mgl32.Mat4
+	Projection(aspect float32) mgl32.Mat4
+	HandleMouse(dx, dy float64)
+	HandleScroll(dy float64)
+	HandleKeys(w *glfw.Window, dt float64)
+	Reframe(bounds Bounds)
+}
+
+func boundsCenterAndSize(bounds Bounds) (center mgl32.Vec3, maxSize float32) {
+	center = mgl32.Vec3{
+		(bounds.minX + bounds.maxX) / 2,
+		(bounds.minY + bounds.maxY) / 2,
+		(bounds.minZ + bounds.maxZ) / 2,
+	}
+	sizeX := bounds.maxX - bounds.minX
+	sizeY := bounds.maxY - bounds.minY
+	sizeZ := bounds.maxZ - bounds.minZ
+	maxSize = float32(math.Max(float64(sizeX), math.Max(float64(sizeY), float64(sizeZ))))
+	if maxSize == 0 {
+		maxSize = 1
+	}
+	return center, maxSize
+}
+
+func eulerDirection(yaw, pitch float64) mgl32.Vec3 {
+	return mgl32.Vec3{
+		float32(math.Cos(mgl32.DegToRad(float32(yaw))) * math.Cos(mgl32.DegToRad(float32(pitch)))),
+		float32(math.Sin(mgl32.DegToRad(float32(pitch)))),
+		float32(math.Sin(mgl32.DegToRad(float32(yaw))) * math.Cos(mgl32.DegToRad(float32(pitch)))),
+	}
+}
+
+func clampPitch(pitch float64) float64 {
+	if pitch > 89.0 {
+		return 89.0
+	}
+	if pitch < -89.0 {
+		return -89.0
+	}
+	return pitch
+}
+
+// FPSCamera is a free-fly first-person camera: WASD translates along the
+// view direction, the mouse looks around, and pitch is clamped to avoid
+// gimbal flip. This mirrors the viewer's original (global-variable) camera
+// behavior.
+type FPSCamera struct {
+	pos        mgl32.Vec3
+	front      mgl32.Vec3
+	up         mgl32.Vec3
+	yaw, pitch float64
+	far        float32
+}
+
+// NewFPSCamera builds an FPSCamera already framed to bounds.
+func NewFPSCamera(bounds Bounds) *FPSCamera {
+	c := &FPSCamera{up: mgl32.Vec3{0, 1, 0}}
+	c.Reframe(bounds)
+	return c
+}
+
+func (c *FPSCamera) Reframe(bounds Bounds) {
+	center, maxSize := boundsCenterAndSize(bounds)
+
+	c.yaw = -90.0
+	c.pitch = -20.0
+	c.front = eulerDirection(c.yaw, c.pitch).Normalize()
+
+	viewDistance := maxSize * 0.8
+	c.pos = mgl32.Vec3{center.X(), center.Y() + maxSize*0.3, center.Z() + viewDistance}
+	c.far = maxSize * 10
+}
+
+func (c *FPSCamera) View() mgl32.Mat4 {
+	return mgl32.LookAtV(c.pos, c.pos.Add(c.front), c.up)
+}
+
+func (c *FPSCamera) Projection(aspect float32) mgl32.Mat4 {
+	return mgl32.Perspective(mgl32.DegToRad(cameraFOV), aspect, cameraNear, c.far)
+}
+
+func (c *FPSCamera) HandleMouse(dx, dy float64) {
+	c.yaw += dx * mouseSensitivity
+	c.pitch = clampPitch(c.pitch + dy*mouseSensitivity)
+	c.front = eulerDirection(c.yaw, c.pitch).Normalize()
+}
+
+func (c *FPSCamera) HandleScroll(dy float64) {
+	// FPS mode has no scroll behavior; speed is cycled with shift instead.
+}
+
+func (c *FPSCamera) HandleKeys(w *glfw.Window, dt float64) {
+	speed := float32(baseSpeed * speedMultipliers[currentSpeedIndex] * dt)
+
+	// Recompute right from front x world-up (not c.up) so strafing stays
+	// level even while looking up or down.
+	right := c.front.Cross(mgl32.Vec3{0, 1, 0}).Normalize()
+
+	if w.GetKey(glfw.KeyW) == glfw.Press {
+		c.pos = c.pos.Add(c.front.Mul(speed))
+	}
+	if w.GetKey(glfw.KeyS) == glfw.Press {
+		c.pos = c.pos.Sub(c.front.Mul(speed))
+	}
+	if w.GetKey(glfw.KeyA) == glfw.Press {
+		c.pos = c.pos.Sub(right.Mul(speed))
+	}
+	if w.GetKey(glfw.KeyD) == glfw.Press {
+		c.pos = c.pos.Add(right.Mul(speed))
+	}
+}
+
+// OrbitCamera orbits a target point (defaulting to the scene bounds
+// center); right-mouse drag updates yaw/pitch and the scroll wheel zooms
+// by adjusting distance.
+type OrbitCamera struct {
+	target     mgl32.Vec3
+	yaw, pitch float64
+	distance   float64
+	far        float32
+}
+
+// NewOrbitCamera builds an OrbitCamera already framed to bounds.
+func NewOrbitCamera(bounds Bounds) *OrbitCamera {
+	c := &OrbitCamera{}
+	c.Reframe(bounds)
+	return c
+}
+
+func (c *OrbitCamera) Reframe(bounds Bounds) {
+	center, maxSize := boundsCenterAndSize(bounds)
+	c.target = center
+	c.yaw = -90.0
+	c.pitch = -20.0
+	c.distance = float64(maxSize * 0.8)
+	c.far = maxSize * 10
+}
+
+func (c *OrbitCamera) eye() mgl32.Vec3 {
+	dir := eulerDirection(c.yaw, c.pitch).Normalize()
+	return c.target.Sub(dir.Mul(float32(c.distance)))
+}
+
+func (c *OrbitCamera) View() mgl32.Mat4 {
+	return mgl32.LookAtV(c.eye(), c.target, mgl32.Vec3{0, 1, 0})
+}
+
+func (c *OrbitCamera) Projection(aspect float32) mgl32.Mat4 {
+	return mgl32.Perspective(mgl32.DegToRad(cameraFOV), aspect, cameraNear, c.far)
+}
+
+func (c *OrbitCamera) HandleMouse(dx, dy float64) {
+	c.yaw += dx * mouseSensitivity
+	c.pitch = clampPitch(c.pitch + dy*mouseSensitivity)
+}
+
+func (c *OrbitCamera) HandleScroll(dy float64) {
+	c.distance -= dy * (c.distance * 0.1)
+	if c.distance < 0.01 {
+		c.distance = 0.01
+	}
+}
+
+func (c *OrbitCamera) HandleKeys(w *glfw.Window, dt float64) {
+	// Orbit mode is mouse/scroll driven only; no WASD translation.
+}
+
+// OrthoTopDownCamera looks straight down the Y axis for map-style
+// inspection: right-mouse drag pans in the XZ plane and the scroll wheel
+// zooms by adjusting the orthographic view height.
+type OrthoTopDownCamera struct {
+	target     mgl32.Vec3 // XZ position looked down at; Y holds the eye height
+	viewHeight float32    // half-height of the ortho frustum in world units
+	far        float32
+}
+
+// NewOrthoTopDownCamera builds an OrthoTopDownCamera already framed to bounds.
+func NewOrthoTopDownCamera(bounds Bounds) *OrthoTopDownCamera {
+	c := &OrthoTopDownCamera{}
+	c.Reframe(bounds)
+	return c
+}
+
+func (c *OrthoTopDownCamera) Reframe(bounds Bounds) {
+	center, maxSize := boundsCenterAndSize(bounds)
+	c.target = mgl32.Vec3{center.X(), bounds.maxY + maxSize, center.Z()}
+	c.viewHeight = maxSize * 0.6
+	c.far = maxSize * 10
+}
+
+func (c *OrthoTopDownCamera) View() mgl32.Mat4 {
+	// Looking straight down -Y makes the forward/up pair colinear for a
+	// regular LookAt; use -Z as "up" so the image reads like a map.
+	return mgl32.LookAtV(c.target, c.target.Sub(mgl32.Vec3{0, 1, 0}), mgl32.Vec3{0, 0, -1})
+}
+
+func (c *OrthoTopDownCamera) Projection(aspect float32) mgl32.Mat4 {
+	halfWidth := c.viewHeight * aspect
+	return mgl32.Ortho(-halfWidth, halfWidth, -c.viewHeight, c.viewHeight, cameraNear, c.far)
+}
+
+func (c *OrthoTopDownCamera) HandleMouse(dx, dy float64) {
+	panScale := c.viewHeight / 300.0
+	c.target = c.target.Add(mgl32.Vec3{float32(-dx) * panScale, 0, float32(dy) * panScale})
+}
+
+func (c *OrthoTopDownCamera) HandleScroll(dy float64) {
+	c.viewHeight -= float32(dy) * (c.viewHeight * 0.1)
+	if c.viewHeight < 0.01 {
+		c.viewHeight = 0.01
+	}
+}
+
+func (c *OrthoTopDownCamera) HandleKeys(w *glfw.Window, dt float64) {
+	// Top-down mode is mouse/scroll driven only; no WASD translation.
+}
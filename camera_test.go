@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func matApproxEqual(t *testing.T, got, want mgl32.Mat4, tolerance float32) {
+	t.Helper()
+	for i := 0; i < 16; i++ {
+		if diff := float32(math.Abs(float64(got[i] - want[i]))); diff > tolerance {
+			t.Fatalf("matrix element %d differs: got %v want %v (diff %v)\ngot:  %v\nwant: %v", i, got[i], want[i], diff, got, want)
+		}
+	}
+}
+
+var testBounds = Bounds{
+	minX: 0, minY: 0, minZ: 0,
+	maxX: 10, maxY: 10, maxZ: 10,
+}
+
+func TestFPSCameraViewMatrixFromBounds(t *testing.T) {
+	cam := NewFPSCamera(testBounds)
+
+	wantPos := mgl32.Vec3{5, 5 + 10*0.3, 5 + 10*0.8}
+	wantFront := eulerDirection(-90, -20).Normalize()
+	wantView := mgl32.LookAtV(wantPos, wantPos.Add(wantFront), mgl32.Vec3{0, 1, 0})
+
+	matApproxEqual(t, cam.View(), wantView, 1e-4)
+}
+
+func TestOrbitCameraViewMatrixFromBounds(t *testing.T) {
+	cam := NewOrbitCamera(testBounds)
+
+	target := mgl32.Vec3{5, 5, 5}
+	dir := eulerDirection(-90, -20).Normalize()
+	wantEye := target.Sub(dir.Mul(10 * 0.8))
+	wantView := mgl32.LookAtV(wantEye, target, mgl32.Vec3{0, 1, 0})
+
+	matApproxEqual(t, cam.View(), wantView, 1e-4)
+}
+
+func TestOrthoTopDownCameraViewMatrixFromBounds(t *testing.T) {
+	cam := NewOrthoTopDownCamera(testBounds)
+
+	target := mgl32.Vec3{5, 10 + 10, 5}
+	wantView := mgl32.LookAtV(target, target.Sub(mgl32.Vec3{0, 1, 0}), mgl32.Vec3{0, 0, -1})
+
+	matApproxEqual(t, cam.View(), wantView, 1e-4)
+}
+
+func TestOrbitCameraScrollZoomsDistance(t *testing.T) {
+	cam := NewOrbitCamera(testBounds)
+	before := cam.distance
+
+	cam.HandleScroll(1)
+	if cam.distance >= before {
+		t.Fatalf("expected scroll-in to shrink distance, got %v (was %v)", cam.distance, before)
+	}
+}
+
+func TestOrthoTopDownCameraPansInXZPlane(t *testing.T) {
+	cam := NewOrthoTopDownCamera(testBounds)
+	startY := cam.target.Y()
+
+	cam.HandleMouse(10, 0)
+
+	if cam.target.Y() != startY {
+		t.Fatalf("pan should not move target vertically, got Y=%v want %v", cam.target.Y(), startY)
+	}
+	if cam.target.X() == 5 {
+		t.Fatalf("expected pan to move target on X")
+	}
+}
+
+func TestReframeRederivesFarPlaneForAllModes(t *testing.T) {
+	fps := NewFPSCamera(Bounds{})
+	orbit := NewOrbitCamera(Bounds{})
+	topDown := NewOrthoTopDownCamera(Bounds{})
+
+	fps.Reframe(testBounds)
+	orbit.Reframe(testBounds)
+	topDown.Reframe(testBounds)
+
+	// Bounds{} frames with maxSize 1 (far 10); testBounds has maxSize 10.
+	const wantFar = float32(10 * 10)
+	if fps.far != wantFar || orbit.far != wantFar || topDown.far != wantFar {
+		t.Fatalf("expected all cameras to derive far %v from the new bounds, got fps=%v orbit=%v topDown=%v", wantFar, fps.far, orbit.far, topDown.far)
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const hudVertexShaderSource = `
+    #version 410
+    layout (location = 0) in vec2 position;
+
+    uniform mat4 projection;
+    uniform vec4 rect; // x, y, w, h in screen pixels, origin top-left
+
+    void main() {
+        vec2 screenPos = rect.xy + position * rect.zw;
+        gl_Position = projection * vec4(screenPos, 0.0, 1.0);
+    }
+    ` + "\x00"
+
+const hudFragmentShaderSource = `
+    #version 410
+    uniform vec4 hudColor;
+    out vec4 color;
+
+    void main() {
+        color = hudColor;
+    }
+    ` + "\x00"
+
+const (
+	glyphPixel   = 4.0 // size of one font3x5 "pixel" in screen pixels
+	glyphWidth   = 3 * glyphPixel
+	glyphHeight  = 5 * glyphPixel
+	glyphAdvance = glyphWidth + glyphPixel
+	lineAdvance  = glyphHeight + glyphPixel*2
+)
+
+// textRenderer draws font3x5 strings as filled screen-space quads, one per
+// lit glyph pixel. It owns a single reusable unit quad; each glyph pixel is
+// just a different "rect" uniform over the same geometry.
+type textRenderer struct {
+	program uint32
+	quadVAO uint32
+	quadVBO uint32
+}
+
+func newTextRenderer() *textRenderer {
+	t := &textRenderer{program: linkProgram(hudVertexShaderSource, hudFragmentShaderSource)}
+
+	unitQuad := []float32{
+		0, 0,
+		1, 0,
+		1, 1,
+		0, 0,
+		1, 1,
+		0, 1,
+	}
+
+	gl.GenVertexArrays(1, &t.quadVAO)
+	gl.GenBuffers(1, &t.quadVBO)
+	gl.BindVertexArray(t.quadVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, t.quadVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(unitQuad)*4, gl.Ptr(unitQuad), gl.STATIC_DRAW)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 2, gl.FLOAT, false, 0, nil)
+
+	return t
+}
+
+func (t *textRenderer) destroy() {
+	gl.DeleteVertexArrays(1, &t.quadVAO)
+	gl.DeleteBuffers(1, &t.quadVBO)
+	gl.DeleteProgram(t.program)
+}
+
+// begin sets up the screen-space orthographic projection and GL state
+// shared by every quad drawn until end() is called.
+func (t *textRenderer) begin(screenW, screenH int32) {
+	gl.UseProgram(t.program)
+	projection := mgl32.Ortho2D(0, float32(screenW), float32(screenH), 0)
+	gl.UniformMatrix4fv(gl.GetUniformLocation(t.program, gl.Str("projection\x00")), 1, false, &projection[0])
+	gl.Disable(gl.DEPTH_TEST)
+	gl.Enable(gl.BLEND)
+	gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+	gl.BindVertexArray(t.quadVAO)
+}
+
+func (t *textRenderer) end() {
+	gl.Disable(gl.BLEND)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+func (t *textRenderer) rect(x, y, w, h float32, color mgl32.Vec4) {
+	gl.Uniform4f(gl.GetUniformLocation(t.program, gl.Str("rect\x00")), x, y, w, h)
+	gl.Uniform4f(gl.GetUniformLocation(t.program, gl.Str("hudColor\x00")), color.X(), color.Y(), color.Z(), color.W())
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+}
+
+// text draws s starting at (x, y) and returns the bounding width/height it
+// occupied, so callers can lay out clickable rows under it.
+func (t *textRenderer) text(s string, x, y float32, color mgl32.Vec4) (w, h float32) {
+	cursor := x
+	for _, r := range strings.ToUpper(s) {
+		glyph, ok := font3x5[r]
+		if !ok {
+			cursor += glyphAdvance
+			continue
+		}
+		for row := 0; row < 5; row++ {
+			for col := 0; col < 3; col++ {
+				if glyph[row][col] == '#' {
+					t.rect(cursor+float32(col)*glyphPixel, y+float32(row)*glyphPixel, glyphPixel, glyphPixel, color)
+				}
+			}
+		}
+		cursor += glyphAdvance
+	}
+	return cursor - x, glyphHeight
+}
+
+// hudRow is a clickable region of the HUD, hit-tested in screen pixels with
+// origin top-left (matching GLFW cursor coordinates).
+type hudRow struct {
+	x, y, w, h float32
+	onClick    func()
+}
+
+// HUD is the in-window immediate-mode overlay: camera/FPS stats, a
+// visibility checkbox per loaded mesh, a solid/wireframe/both radio, a
+// background color swatch cycle, and a "Load OBJ" button replacing the
+// F1-only reload path.
+type HUD struct {
+	text *textRenderer
+	rows []hudRow
+}
+
+// NewHUD links the HUD's text-quad program.
+func NewHUD() *HUD {
+	return &HUD{text: newTextRenderer()}
+}
+
+func (h *HUD) Destroy() {
+	h.text.destroy()
+}
+
+var (
+	hudColorText   = mgl32.Vec4{1, 1, 1, 1}
+	hudColorOn     = mgl32.Vec4{0.3, 1.0, 0.3, 1}
+	hudColorButton = mgl32.Vec4{1.0, 0.8, 0.2, 1}
+)
+
+// Draw renders the overlay for the current frame and rebuilds the click
+// regions mouseButtonCallback hit-tests against. The screen size comes from
+// the package-level viewport.
+func (h *HUD) Draw(scene Scene) {
+	h.rows = h.rows[:0]
+	h.text.begin(viewport.Width, viewport.Height)
+
+	x, y := float32(10), float32(10)
+
+	_, lh := h.text.text(fmt.Sprintf("CAM %s MODE", cameraModeName()), x, y, hudColorText)
+	y += lh + glyphPixel*2
+
+	pos := activeCamera().View().Inv().Col(3)
+	_, lh = h.text.text(fmt.Sprintf("POS %.1f %.1f %.1f", pos.X(), pos.Y(), pos.Z()), x, y, hudColorText)
+	y += lh + glyphPixel*2
+
+	_, lh = h.text.text(fmt.Sprintf("FPS %.0f MS %.1f", currentFPS, currentMS), x, y, hudColorText)
+	y += lh + glyphPixel*2
+
+	for i, mesh := range scene.meshes {
+		box := "[ ]"
+		color := hudColorText
+		if mesh.visible {
+			box = "[X]"
+			color = hudColorOn
+		}
+		name := "MESH"
+		if i < len(scene.filenames) {
+			name = shortName(scene.filenames[i])
+		}
+		line := fmt.Sprintf("%s %s V%d T%d", box, name, len(mesh.vertices)/3, len(mesh.indices)/3)
+		w, lh := h.text.text(line, x, y, color)
+
+		meshIdx := i
+		h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: func() {
+			scene.meshes[meshIdx].visible = !scene.meshes[meshIdx].visible
+		}})
+		y += lh + glyphPixel*2
+	}
+
+	radioLine := fmt.Sprintf("MODE %s [PRESS M]", renderMode)
+	w, lh := h.text.text(radioLine, x, y, hudColorButton)
+	h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: cycleRenderMode})
+	y += lh + glyphPixel*2
+
+	materialLine := fmt.Sprintf("MATERIAL %s [PRESS N]", materialMode)
+	w, lh = h.text.text(materialLine, x, y, hudColorButton)
+	h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: cycleMaterialMode})
+	y += lh + glyphPixel*2
+
+	shaderLine := "RELOAD SHADERS [F5]"
+	w, lh = h.text.text(shaderLine, x, y, hudColorButton)
+	h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: func() { glProgram = reloadShaders(glProgram) }})
+	y += lh + glyphPixel*2
+
+	bgLine := "BG COLOR"
+	w, lh = h.text.text(bgLine, x, y, hudColorButton)
+	h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: cycleBackgroundColor})
+	y += lh + glyphPixel*2
+
+	loadLine := "LOAD OBJ..."
+	w, lh = h.text.text(loadLine, x, y, hudColorButton)
+	h.rows = append(h.rows, hudRow{x: x, y: y, w: w, h: lh, onClick: triggerLoadOBJ})
+	y += lh + glyphPixel*2
+
+	if sel := picker.Selected; sel != nil {
+		_, lh = h.text.text(fmt.Sprintf("PICK %s TRI #%d", shortName(sel.Filename), sel.TriIndex), x, y, hudColorText)
+		y += lh + glyphPixel*2
+
+		_, lh = h.text.text(fmt.Sprintf("V0 %.1f %.1f %.1f", sel.V0.X(), sel.V0.Y(), sel.V0.Z()), x, y, hudColorText)
+		y += lh + glyphPixel*2
+		_, lh = h.text.text(fmt.Sprintf("V1 %.1f %.1f %.1f", sel.V1.X(), sel.V1.Y(), sel.V1.Z()), x, y, hudColorText)
+		y += lh + glyphPixel*2
+		_, lh = h.text.text(fmt.Sprintf("V2 %.1f %.1f %.1f", sel.V2.X(), sel.V2.Y(), sel.V2.Z()), x, y, hudColorText)
+		y += lh + glyphPixel*2
+
+		_, lh = h.text.text(fmt.Sprintf("NORMAL %.2f %.2f %.2f", sel.Normal.X(), sel.Normal.Y(), sel.Normal.Z()), x, y, hudColorText)
+		y += lh + glyphPixel*2
+
+		_, _ = h.text.text(fmt.Sprintf("AREA %.2f", sel.Area), x, y, hudColorText)
+	}
+
+	h.text.end()
+}
+
+// HandleClick consumes a left-click at window coordinates (x, y) if it
+// landed on a HUD row, returning true when it did so callers can skip
+// picking/path-mode handling for that click.
+func (h *HUD) HandleClick(x, y float64) bool {
+	for _, row := range h.rows {
+		if float32(x) >= row.x && float32(x) <= row.x+row.w &&
+			float32(y) >= row.y && float32(y) <= row.y+row.h {
+			row.onClick()
+			return true
+		}
+	}
+	return false
+}
+
+func cameraModeName() string {
+	switch activeCamera().(type) {
+	case *FPSCamera:
+		return "FPS"
+	case *OrbitCamera:
+		return "ORBIT"
+	case *OrthoTopDownCamera:
+		return "TOPDOWN"
+	default:
+		return "?"
+	}
+}
+
+func cycleRenderMode() {
+	renderMode = (renderMode + 1) % 3
+}
+
+func cycleBackgroundColor() {
+	bgColorIdx = (bgColorIdx + 1) % len(bgPalette)
+	c := bgPalette[bgColorIdx]
+	gl.ClearColor(c.X(), c.Y(), c.Z(), 1.0)
+}
+
+func triggerLoadOBJ() {
+	newFiles, err := selectOBJFiles(lastDirectory)
+	if err != nil || len(newFiles) == 0 {
+		return
+	}
+	cleanupScene(&scene)
+	scene = loadAllMeshes(glProgram, newFiles)
+}
+
+func shortName(path string) string {
+	idx := strings.LastIndexAny(path, `/\`)
+	if idx >= 0 {
+		return path[idx+1:]
+	}
+	return path
+}
@@ -25,17 +25,53 @@ const (
 	mouseSensitivity = 0.1
 )
 
+// RenderMode selects which polygon modes renderScene draws each mesh with.
+type RenderMode int
+
+const (
+	RenderSolidWireframe RenderMode = iota
+	RenderSolid
+	RenderWireframe
+)
+
+func (m RenderMode) String() string {
+	switch m {
+	case RenderSolid:
+		return "SOLID"
+	case RenderWireframe:
+		return "WIREFRAME"
+	default:
+		return "BOTH"
+	}
+}
+
+// Viewport tracks the live framebuffer size, updated by GLFW's
+// framebuffer-size callback whenever the window is resized.
+type Viewport struct {
+	Width, Height int32
+}
+
+func (v Viewport) Aspect() float32 {
+	return float32(v.Width) / float32(v.Height)
+}
+
 var (
-	cameraPos   = mgl32.Vec3{0, 0, 0}
-	cameraFront = mgl32.Vec3{0, 0, -1}
-	cameraUp    = mgl32.Vec3{0, 1, 0}
-	yaw         = -90.0
-	pitch       = 0.0
-	lastX       = float64(width / 2)
-	lastY       = float64(height / 2)
-	firstMouse  = true
-	deltaTime   = 0.0
-	lastFrame   = 0.0
+	viewport = Viewport{Width: width, Height: height}
+
+	// Fullscreen toggle state, captured before switching so F11 can
+	// restore the previous windowed position/size.
+	windowedX, windowedY, windowedW, windowedH int
+	isFullscreen                               bool
+
+	cameras        []Camera
+	cameraIndex    int
+	cameraDragging bool
+
+	lastX      = float64(width / 2)
+	lastY      = float64(height / 2)
+	firstMouse = true
+	deltaTime  = 0.0
+	lastFrame  = 0.0
 
 	// Speed multiplier variables
 	speedMultipliers  = []float64{1, 5, 10, 20, 40}
@@ -44,54 +80,34 @@ var (
 	
 	// Directory tracking
 	lastDirectory string
-)
-
-const (
-	vertexShaderSource = `
-    #version 410
-    layout (location = 0) in vec3 position;
-    
-    uniform mat4 projection;
-    uniform mat4 camera;
-    uniform mat4 model;
-    
-    out vec3 FragPos;
-    
-    void main() {
-        FragPos = vec3(model * vec4(position, 1.0));
-        gl_Position = projection * camera * model * vec4(position, 1.0);
-    }
-    ` + "\x00"
 
-	fragmentShaderSource = `
-    #version 410
-    in vec3 FragPos;
-    uniform bool isWireframe;
-    
-    out vec4 color;
-    
-    void main() {
-        if (isWireframe) {
-            color = vec4(0.0, 0.0, 0.0, 1.0); // Black wireframe
-        } else {
-            vec3 lightPos = vec3(2000.0, 1000.0, 2000.0);
-            vec3 lightColor = vec3(1.0, 1.0, 1.0);
-            vec3 objectColor = vec3(0.5, 0.7, 1.0); // Light blue color
-            
-            // Ambient
-            float ambientStrength = 0.3;
-            vec3 ambient = ambientStrength * lightColor;
-            
-            // Diffuse
-            vec3 lightDir = normalize(lightPos - FragPos);
-            float diff = max(dot(normalize(vec3(0.0, 1.0, 0.0)), lightDir), 0.0);
-            vec3 diffuse = diff * lightColor;
-            
-            vec3 result = (ambient + diffuse) * objectColor;
-            color = vec4(result, 1.0);
-        }
-    }
-    ` + "\x00"
+	// Post-processing
+	postProcessor *PostProcessor
+	postMode      = PostProcessFXAA
+
+	// Picking
+	picker         *Picker
+	lastProjection mgl32.Mat4
+	lastView       mgl32.Mat4
+
+	// Path mode
+	pathMode     bool
+	navGraph     *NavGraph
+	pathRenderer *PathRenderer
+	pathStartTri = -1
+
+	// HUD
+	hud        *HUD
+	hudVisible = true
+	renderMode = RenderSolidWireframe
+	bgPalette  = []mgl32.Vec3{{0.2, 0.2, 0.2}, {0.0, 0.0, 0.0}, {1, 1, 1}, {0.05, 0.05, 0.15}}
+	bgColorIdx = 0
+	currentFPS float64
+	currentMS  float64
+	glProgram  uint32
+
+	// Shader material preset, applied by mesh.frag's "material" uniform.
+	materialMode = MaterialFlat
 )
 
 type Bounds struct {
@@ -106,11 +122,13 @@ type MeshData struct {
 	vao      uint32
 	vbo      uint32
 	ebo      uint32
+	visible  bool
 }
 
 type Scene struct {
-    meshes []MeshData
-    bounds Bounds
+    meshes    []MeshData
+    bounds    Bounds
+    filenames []string
 }
 
 var scene Scene
@@ -133,12 +151,17 @@ func main() {
     window, program := initializeWindow(strings.Join(filenames, ", "))
     defer window.Destroy()
 
+    glProgram = program
     scene = loadAllMeshes(program, filenames)
     
     for !window.ShouldClose() {
         currentFrame := glfw.GetTime()
         deltaTime = currentFrame - lastFrame
         lastFrame = currentFrame
+        if deltaTime > 0 {
+            currentFPS = 1.0 / deltaTime
+            currentMS = deltaTime * 1000.0
+        }
 
         processInput(window)
 
@@ -152,7 +175,7 @@ func main() {
             window.SetTitle(fmt.Sprintf("NavMesh Viewer - %s", strings.Join(newFiles, ", ")))
         }
 
-        renderScene(window, program, scene)
+        renderScene(window, glProgram, scene)
 
         window.SwapBuffers()
         glfw.PollEvents()
@@ -216,7 +239,8 @@ func selectOBJFiles(startDir string) ([]string, error) {
 func loadAllMeshes(program uint32, filenames []string) Scene {
     var newScene Scene
     newScene.meshes = make([]MeshData, 0, len(filenames))
-    
+    newScene.filenames = filenames
+
     // Initialize bounds with first vertex of first mesh
     firstMesh := loadSingleMesh(program, filenames[0])
     newScene.bounds = firstMesh.bounds
@@ -267,6 +291,7 @@ func loadSingleMesh(program uint32, filename string) MeshData {
         vao:      vao,
         vbo:      vbo,
         ebo:      ebo,
+        visible:  true,
     }
 }
 
@@ -282,17 +307,13 @@ func combineBounds(a, b Bounds) Bounds {
 }
 
 func renderScene(window *glfw.Window, program uint32, scene Scene) {
+    postProcessor.Begin(postMode)
     gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
     gl.UseProgram(program)
 
-    // Calculate view and projection matrices based on combined bounds
-    sizeX := scene.bounds.maxX - scene.bounds.minX
-    sizeY := scene.bounds.maxY - scene.bounds.minY
-    sizeZ := scene.bounds.maxZ - scene.bounds.minZ
-    maxSize := float32(math.Max(float64(sizeX), math.Max(float64(sizeY), float64(sizeZ))))
-
-    projection := mgl32.Perspective(mgl32.DegToRad(45.0), float32(width)/float32(height), 0.1, maxSize*10)
-    view := mgl32.LookAtV(cameraPos, cameraPos.Add(cameraFront), cameraUp)
+    // Calculate view and projection matrices from the active camera
+    projection := activeCamera().Projection(viewport.Aspect())
+    view := activeCamera().View()
     model := mgl32.Ident4()
 
     projectionUniform := gl.GetUniformLocation(program, gl.Str("projection\x00"))
@@ -303,29 +324,50 @@ func renderScene(window *glfw.Window, program uint32, scene Scene) {
     gl.UniformMatrix4fv(viewUniform, 1, false, &view[0])
     gl.UniformMatrix4fv(modelUniform, 1, false, &model[0])
 
-    // Render each mesh
+    gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("material\x00")), int32(materialMode))
+    gl.Uniform1f(gl.GetUniformLocation(program, gl.Str("boundsMinY\x00")), scene.bounds.minY)
+    gl.Uniform1f(gl.GetUniformLocation(program, gl.Str("boundsMaxY\x00")), scene.bounds.maxY)
+
+    lastProjection = projection
+    lastView = view
+
+    // Render each visible mesh, per the active solid/wireframe/both mode
     for _, mesh := range scene.meshes {
+        if !mesh.visible {
+            continue
+        }
         gl.BindVertexArray(mesh.vao)
 
-        // Draw solid mesh
-        gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
-        gl.Enable(gl.POLYGON_OFFSET_FILL)
-        gl.PolygonOffset(1.0, 1.0)
-        gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 0)
-        gl.DrawElements(gl.TRIANGLES, int32(len(mesh.indices)), gl.UNSIGNED_INT, nil)
-        gl.Disable(gl.POLYGON_OFFSET_FILL)
-
-        // Draw wireframe overlay
-        gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
-        gl.LineWidth(1.0)
-        gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 1)
-        gl.DrawElements(gl.TRIANGLES, int32(len(mesh.indices)), gl.UNSIGNED_INT, nil)
+        if renderMode == RenderSolid || renderMode == RenderSolidWireframe {
+            gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+            gl.Enable(gl.POLYGON_OFFSET_FILL)
+            gl.PolygonOffset(1.0, 1.0)
+            gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 0)
+            gl.DrawElements(gl.TRIANGLES, int32(len(mesh.indices)), gl.UNSIGNED_INT, nil)
+            gl.Disable(gl.POLYGON_OFFSET_FILL)
+        }
+
+        if renderMode == RenderWireframe || renderMode == RenderSolidWireframe {
+            gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+            gl.LineWidth(1.0)
+            gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 1)
+            gl.DrawElements(gl.TRIANGLES, int32(len(mesh.indices)), gl.UNSIGNED_INT, nil)
+        }
     }
 
     // Reset polygon mode
     gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
 
-    window.SetTitle(fmt.Sprintf("NavMesh Viewer - Speed: %.1fx", speedMultipliers[currentSpeedIndex]))
+    picker.DrawHighlight(program)
+    pathRenderer.Draw(projection, view)
+
+    postProcessor.Composite(postMode)
+
+    if hudVisible {
+        hud.Draw(scene)
+    }
+
+    window.SetTitle(fmt.Sprintf("NavMesh Viewer - Speed: %.1fx - AA: %s - Material: %s", speedMultipliers[currentSpeedIndex], postMode, materialMode))
 }
 
 func cleanupScene(scene *Scene) {
@@ -335,6 +377,14 @@ func cleanupScene(scene *Scene) {
         gl.DeleteBuffers(1, &scene.meshes[i].ebo)
     }
     scene.meshes = nil
+
+    // The nav-graph, current pick, and drawn path all reference triangles
+    // of the mesh data we just freed; drop them so a reload can't leave a
+    // stale selection or corridor pointing at geometry that no longer exists.
+    navGraph = nil
+    pathStartTri = -1
+    picker.Selected = nil
+    pathRenderer.Clear()
 }
 
 func initializeWindow(filename string) (*glfw.Window, uint32) {
@@ -342,7 +392,7 @@ func initializeWindow(filename string) (*glfw.Window, uint32) {
 	glfw.WindowHint(glfw.ContextVersionMinor, 1)
 	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
 	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
-	glfw.WindowHint(glfw.Resizable, glfw.False)
+	glfw.WindowHint(glfw.Resizable, glfw.True)
 	glfw.WindowHint(glfw.Samples, 4)
 
 	window, err := glfw.CreateWindow(width, height, fmt.Sprintf("NavMesh Viewer - %s", filename), nil, nil)
@@ -354,134 +404,87 @@ func initializeWindow(filename string) (*glfw.Window, uint32) {
 	window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
 	window.SetCursorPosCallback(mouseCallback)
 	window.SetKeyCallback(keyCallback)
+	window.SetMouseButtonCallback(mouseButtonCallback)
+	window.SetScrollCallback(scrollCallback)
+	window.SetFramebufferSizeCallback(framebufferSizeCallback)
 
 	if err := gl.Init(); err != nil {
 		log.Fatal(err)
 	}
 
 	program := initializeShaders()
-	
+
 	gl.Enable(gl.DEPTH_TEST)
 	gl.Enable(gl.CULL_FACE)
 	gl.Enable(gl.MULTISAMPLE)
 	gl.ClearColor(0.2, 0.2, 0.2, 1.0)
 
+	postProcessor = NewPostProcessor(viewport.Width, viewport.Height, 4)
+	picker = NewPicker()
+	pathRenderer = NewPathRenderer()
+	hud = NewHUD()
+
 	return window, program
 }
 
-func initializeCamera(bounds Bounds) {
-	// Calculate mesh center
-	centerX := (bounds.minX + bounds.maxX) / 2
-	centerY := (bounds.minY + bounds.maxY) / 2
-	centerZ := (bounds.minZ + bounds.maxZ) / 2
-	
-	// Calculate mesh size
-	sizeX := bounds.maxX - bounds.minX
-	sizeY := bounds.maxY - bounds.minY
-	sizeZ := bounds.maxZ - bounds.minZ
-	maxSize := float32(math.Max(float64(sizeX), math.Max(float64(sizeY), float64(sizeZ))))
-	
-	// Position camera at a reasonable viewing distance
-	viewDistance := maxSize * 0.8
-	cameraPos = mgl32.Vec3{
-		centerX,
-		centerY + maxSize * 0.3,
-		centerZ + viewDistance,
-	}
-	
-	// Reset camera orientation
-	yaw = -90.0
-	pitch = -20.0
-	
-	// Update camera front vector
-	direction := mgl32.Vec3{
-		float32(math.Cos(float64(mgl32.DegToRad(float32(yaw)))) * math.Cos(float64(mgl32.DegToRad(float32(pitch))))),
-		float32(math.Sin(float64(mgl32.DegToRad(float32(pitch))))),
-		float32(math.Sin(float64(mgl32.DegToRad(float32(yaw)))) * math.Cos(float64(mgl32.DegToRad(float32(pitch))))),
+// toggleFullscreen switches window between windowed and borderless
+// fullscreen on the primary monitor, preserving camera state (the cameras
+// themselves are untouched; only the window/monitor binding changes).
+func toggleFullscreen(window *glfw.Window) {
+	if isFullscreen {
+		window.SetMonitor(nil, windowedX, windowedY, windowedW, windowedH, 0)
+		isFullscreen = false
+		return
 	}
-	cameraFront = direction.Normalize()
-}
 
-func loadAndSetupMesh(program uint32, filename string) MeshData {
-	vertices, indices := loadOBJFile(filename)
-	if len(vertices) == 0 || len(indices) == 0 {
-		log.Fatal("No mesh data loaded")
-	}
+	windowedX, windowedY = window.GetPos()
+	windowedW, windowedH = window.GetSize()
 
-	bounds := calculateBounds(vertices)
-	initializeCamera(bounds)
+	monitor := glfw.GetPrimaryMonitor()
+	mode := monitor.GetVideoMode()
+	window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	isFullscreen = true
+}
 
-	var vao uint32
-	gl.GenVertexArrays(1, &vao)
-	gl.BindVertexArray(vao)
+// framebufferSizeCallback keeps the GL viewport, the post-processor's
+// offscreen targets, and the mouse-delta tracking in sync with the live
+// window size whenever it's resized (including entering/leaving
+// fullscreen).
+func framebufferSizeCallback(_ *glfw.Window, w, h int) {
+	if w == 0 || h == 0 {
+		return // minimized
+	}
 
-	var vbo uint32
-	gl.GenBuffers(1, &vbo)
-	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
-	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+	viewport.Width = int32(w)
+	viewport.Height = int32(h)
 
-	var ebo uint32
-	gl.GenBuffers(1, &ebo)
-	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, ebo)
-	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+	gl.Viewport(0, 0, viewport.Width, viewport.Height)
+	postProcessor.Resize(viewport.Width, viewport.Height)
 
-	gl.EnableVertexAttribArray(0)
-	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, nil)
+	// Avoid a sudden look/orbit jump from the old cursor position mapping
+	// onto a different-sized window.
+	firstMouse = true
+}
 
-	return MeshData{
-		vertices: vertices,
-		indices:  indices,
-		bounds:   bounds,
-		vao:      vao,
-		vbo:      vbo,
-		ebo:      ebo,
+// initializeCamera builds the three camera modes on first load and frames
+// all of them to bounds; on later loads (e.g. a reloaded OBJ selection) it
+// just reframes the existing cameras so the active mode carries over.
+func initializeCamera(bounds Bounds) {
+	if cameras == nil {
+		cameras = []Camera{
+			NewFPSCamera(bounds),
+			NewOrbitCamera(bounds),
+			NewOrthoTopDownCamera(bounds),
+		}
+		return
+	}
+	for _, cam := range cameras {
+		cam.Reframe(bounds)
 	}
 }
 
-func render(window *glfw.Window, program uint32, meshData MeshData) {
-	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
-	gl.UseProgram(program)
-
-	maxSize := float32(math.Max(
-		float64(meshData.bounds.maxX-meshData.bounds.minX),
-		math.Max(
-			float64(meshData.bounds.maxY-meshData.bounds.minY),
-			float64(meshData.bounds.maxZ-meshData.bounds.minZ),
-		),
-	))
-
-	projection := mgl32.Perspective(mgl32.DegToRad(45.0), float32(width)/float32(height), 0.1, maxSize*10)
-	view := mgl32.LookAtV(cameraPos, cameraPos.Add(cameraFront), cameraUp)
-	model := mgl32.Ident4()
-
-	projectionUniform := gl.GetUniformLocation(program, gl.Str("projection\x00"))
-	viewUniform := gl.GetUniformLocation(program, gl.Str("camera\x00"))
-	modelUniform := gl.GetUniformLocation(program, gl.Str("model\x00"))
-
-	gl.UniformMatrix4fv(projectionUniform, 1, false, &projection[0])
-	gl.UniformMatrix4fv(viewUniform, 1, false, &view[0])
-	gl.UniformMatrix4fv(modelUniform, 1, false, &model[0])
-
-	gl.BindVertexArray(meshData.vao)
-
-	// Draw solid mesh
-	gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
-	gl.Enable(gl.POLYGON_OFFSET_FILL)
-	gl.PolygonOffset(1.0, 1.0)
-	gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 0)
-	gl.DrawElements(gl.TRIANGLES, int32(len(meshData.indices)), gl.UNSIGNED_INT, nil)
-	gl.Disable(gl.POLYGON_OFFSET_FILL)
-
-	// Draw wireframe overlay
-	gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
-	gl.LineWidth(1.0)
-	gl.Uniform1i(gl.GetUniformLocation(program, gl.Str("isWireframe\x00")), 1)
-	gl.DrawElements(gl.TRIANGLES, int32(len(meshData.indices)), gl.UNSIGNED_INT, nil)
-
-	// Reset polygon mode
-	gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
-
-	window.SetTitle(fmt.Sprintf("NavMesh Viewer - Speed: %.1fx", speedMultipliers[currentSpeedIndex]))
+func activeCamera() Camera {
+	return cameras[cameraIndex]
 }
 
 func checkFileReload(window *glfw.Window) ([]string, error) {
@@ -499,24 +502,7 @@ func processInput(window *glfw.Window) {
 	}
 	lastShiftState = currentShiftState
 
-	// Calculate current speed
-	speed := float32(baseSpeed * speedMultipliers[currentSpeedIndex] * deltaTime)
-
-	// Calculate right vector from camera front
-	right := cameraFront.Cross(cameraUp).Normalize()
-
-	if window.GetKey(glfw.KeyW) == glfw.Press {
-		cameraPos = cameraPos.Add(cameraFront.Mul(speed))
-	}
-	if window.GetKey(glfw.KeyS) == glfw.Press {
-		cameraPos = cameraPos.Sub(cameraFront.Mul(speed))
-	}
-	if window.GetKey(glfw.KeyA) == glfw.Press {
-		cameraPos = cameraPos.Sub(right.Mul(speed))
-	}
-	if window.GetKey(glfw.KeyD) == glfw.Press {
-		cameraPos = cameraPos.Add(right.Mul(speed))
-	}
+	activeCamera().HandleKeys(window, deltaTime)
 }
 
 func mouseCallback(_ *glfw.Window, xpos float64, ypos float64) {
@@ -532,31 +518,140 @@ func mouseCallback(_ *glfw.Window, xpos float64, ypos float64) {
 	lastX = xpos
 	lastY = ypos
 
-	xoffset *= mouseSensitivity
-	yoffset *= mouseSensitivity
+	// FPS mode looks around freely (cursor is locked); the orbit and
+	// top-down modes only move while the right mouse button is held, so
+	// the left button stays free for picking.
+	if _, isFPS := activeCamera().(*FPSCamera); isFPS || cameraDragging {
+		activeCamera().HandleMouse(xoffset, yoffset)
+	}
+}
+
+func scrollCallback(_ *glfw.Window, _ float64, yoffset float64) {
+	activeCamera().HandleScroll(yoffset)
+}
+
+func mouseButtonCallback(window *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	if button == glfw.MouseButtonRight {
+		cameraDragging = action == glfw.Press
+		return
+	}
 
-	yaw += xoffset
-	pitch += yoffset
+	if button != glfw.MouseButtonLeft || action != glfw.Press {
+		return
+	}
 
-	if pitch > 89.0 {
-		pitch = 89.0
+	if hudVisible {
+		x, y := window.GetCursorPos()
+		if hud.HandleClick(x, y) {
+			return
+		}
+	}
+
+	if pathMode {
+		handlePathClick(window)
+		return
+	}
+
+	_, isFPS := activeCamera().(*FPSCamera)
+	picker.Pick(window, scene, lastProjection, lastView, isFPS)
+}
+
+// handlePathClick implements the two-click start/goal workflow for path
+// mode: the first click picks the start triangle, the second picks the
+// goal, runs A*, smooths the corridor with the funnel algorithm, and
+// uploads the result to pathRenderer. A third click starts a new path.
+func handlePathClick(window *glfw.Window) {
+	_, isFPS := activeCamera().(*FPSCamera)
+	sel := picker.Pick(window, scene, lastProjection, lastView, isFPS)
+	if sel == nil {
+		return
+	}
+
+	if navGraph == nil {
+		navGraph = BuildNavGraph(scene)
+	}
+
+	clicked := navGraph.TriangleAt(sel.MeshIndex, sel.TriIndex)
+	if clicked < 0 {
+		return
+	}
+
+	if pathStartTri < 0 {
+		pathStartTri = clicked
+		pathRenderer.Clear()
+		return
 	}
-	if pitch < -89.0 {
-		pitch = -89.0
+
+	path := navGraph.FindPath(pathStartTri, clicked)
+	if path == nil {
+		log.Printf("path mode: no route between triangle %d and %d", pathStartTri, clicked)
+		pathStartTri = -1
+		return
 	}
 
-	direction := mgl32.Vec3{
-		float32(math.Cos(float64(mgl32.DegToRad(float32(yaw)))) * math.Cos(float64(mgl32.DegToRad(float32(pitch))))),
-		float32(math.Sin(float64(mgl32.DegToRad(float32(pitch))))),
-		float32(math.Sin(float64(mgl32.DegToRad(float32(yaw)))) * math.Cos(float64(mgl32.DegToRad(float32(pitch))))),
+	corridor := make([]navTriangle, len(path))
+	for i, node := range path {
+		corridor[i] = navGraph.triangles[node]
 	}
-	cameraFront = direction.Normalize()
+
+	start := navGraph.triangles[pathStartTri].centroid
+	goal := navGraph.triangles[clicked].centroid
+	smoothed := navGraph.Funnel(path, start, goal)
+
+	pathRenderer.SetCorridor(corridor)
+	pathRenderer.SetPath(smoothed)
+
+	pathStartTri = -1
 }
 
 func keyCallback(window *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
 	if key == glfw.KeyEscape && action == glfw.Press {
 		window.SetShouldClose(true)
 	}
+
+	if key == glfw.KeyF2 && action == glfw.Press {
+		postMode = (postMode + 1) % 3
+	}
+
+	if key == glfw.KeyP && action == glfw.Press {
+		pathMode = !pathMode
+		pathStartTri = -1
+		if !pathMode {
+			pathRenderer.Clear()
+		}
+	}
+
+	if key == glfw.KeyH && action == glfw.Press {
+		hudVisible = !hudVisible
+	}
+
+	if key == glfw.KeyM && action == glfw.Press {
+		cycleRenderMode()
+	}
+
+	if key == glfw.KeyF11 && action == glfw.Press {
+		toggleFullscreen(window)
+	}
+
+	if key == glfw.KeyF5 && action == glfw.Press {
+		glProgram = reloadShaders(glProgram)
+	}
+
+	if key == glfw.KeyN && action == glfw.Press {
+		cycleMaterialMode()
+	}
+
+	if key == glfw.KeyTab && action == glfw.Press {
+		cameraIndex = (cameraIndex + 1) % len(cameras)
+		activeCamera().Reframe(scene.bounds)
+
+		if _, isFPS := activeCamera().(*FPSCamera); isFPS {
+			window.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+		} else {
+			window.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+		}
+		firstMouse = true
+	}
 }
 
 func calculateBounds(vertices []float32) Bounds {
@@ -633,11 +728,23 @@ func loadOBJFile(filename string) ([]float32, []uint32) {
 	return vertices, indices
 }
 
+// initializeShaders reads mesh.vert/mesh.frag from shaders/ and links the
+// main mesh program. Unlike reloadShaders, a failure here is fatal: there's
+// no previous program to fall back to at startup.
 func initializeShaders() uint32 {
+	vertexSource, err := readShaderSource(vertexShaderPath)
+	if err != nil {
+		log.Fatal("Failed to read vertex shader: ", err)
+	}
+	fragmentSource, err := readShaderSource(fragmentShaderPath)
+	if err != nil {
+		log.Fatal("Failed to read fragment shader: ", err)
+	}
+
 	program := gl.CreateProgram()
-	
-	vertexShader := compileShader(vertexShaderSource, gl.VERTEX_SHADER)
-	fragmentShader := compileShader(fragmentShaderSource, gl.FRAGMENT_SHADER)
+
+	vertexShader := compileShader(vertexSource, gl.VERTEX_SHADER)
+	fragmentShader := compileShader(fragmentSource, gl.FRAGMENT_SHADER)
 
 	gl.AttachShader(program, vertexShader)
 	gl.AttachShader(program, fragmentShader)
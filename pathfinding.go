@@ -0,0 +1,304 @@
+package main
+
+import (
+	"container/heap"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// edgeKey identifies a shared edge by its two (sorted) vertex indices,
+// scoped to a mesh since vertex indices are mesh-local.
+type edgeKey struct {
+	mesh   int
+	lo, hi uint32
+}
+
+// navTriangle is one node of the nav-graph: a triangle plus the shared
+// edges and centroid it was built from.
+type navTriangle struct {
+	mesh       int
+	tri        int // triangle index within mesh.indices/3
+	centroid   mgl32.Vec3
+	v0, v1, v2 mgl32.Vec3
+	neighbors  []int                 // indices into NavGraph.triangles
+	sharedEdge map[int][2]mgl32.Vec3 // neighbor triangle index -> shared edge endpoints
+}
+
+// NavGraph is the triangle-adjacency graph used for A* pathfinding over a
+// loaded navmesh. Two triangles are neighbors iff they share exactly two
+// vertex indices.
+type NavGraph struct {
+	triangles []navTriangle
+}
+
+// BuildNavGraph walks every mesh's triangle list and links triangles that
+// share an edge (two vertex indices) via a hash map keyed by the sorted
+// edge pair.
+func BuildNavGraph(scene Scene) *NavGraph {
+	graph := &NavGraph{}
+	edgeOwners := make(map[edgeKey][]int)
+
+	for meshIdx, mesh := range scene.meshes {
+		for tri := 0; tri+2 < len(mesh.indices); tri += 3 {
+			i0, i1, i2 := mesh.indices[tri], mesh.indices[tri+1], mesh.indices[tri+2]
+			v0 := vertexAt(mesh.vertices, i0)
+			v1 := vertexAt(mesh.vertices, i1)
+			v2 := vertexAt(mesh.vertices, i2)
+
+			node := navTriangle{
+				mesh:       meshIdx,
+				tri:        tri / 3,
+				centroid:   v0.Add(v1).Add(v2).Mul(1.0 / 3.0),
+				v0:         v0,
+				v1:         v1,
+				v2:         v2,
+				sharedEdge: make(map[int][2]mgl32.Vec3),
+			}
+			nodeIdx := len(graph.triangles)
+			graph.triangles = append(graph.triangles, node)
+
+			for _, edge := range [][2]uint32{{i0, i1}, {i1, i2}, {i2, i0}} {
+				key := sortedEdgeKey(meshIdx, edge[0], edge[1])
+				edgeOwners[key] = append(edgeOwners[key], nodeIdx)
+			}
+		}
+	}
+
+	for _, owners := range edgeOwners {
+		if len(owners) != 2 {
+			continue // boundary edge, or (malformed) edge shared by >2 triangles
+		}
+		a, b := owners[0], owners[1]
+		edge := sharedEdgeOf(&graph.triangles[a], &graph.triangles[b])
+		graph.triangles[a].neighbors = append(graph.triangles[a].neighbors, b)
+		graph.triangles[a].sharedEdge[b] = edge
+		graph.triangles[b].neighbors = append(graph.triangles[b].neighbors, a)
+		graph.triangles[b].sharedEdge[a] = edge
+	}
+
+	return graph
+}
+
+func sortedEdgeKey(mesh int, a, b uint32) edgeKey {
+	if a > b {
+		a, b = b, a
+	}
+	return edgeKey{mesh: mesh, lo: a, hi: b}
+}
+
+// sharedEdgeOf returns the two vertex positions a and b have in common,
+// identified by matching positions rather than indices since navTriangle
+// stores only vertex positions.
+func sharedEdgeOf(a, b *navTriangle) [2]mgl32.Vec3 {
+	av := [3]mgl32.Vec3{a.v0, a.v1, a.v2}
+	bv := [3]mgl32.Vec3{b.v0, b.v1, b.v2}
+
+	var shared []mgl32.Vec3
+	for _, va := range av {
+		for _, vb := range bv {
+			if va.ApproxEqual(vb) {
+				shared = append(shared, va)
+			}
+		}
+	}
+	if len(shared) < 2 {
+		return [2]mgl32.Vec3{}
+	}
+	return [2]mgl32.Vec3{shared[0], shared[1]}
+}
+
+// TriangleAt returns the graph index of the triangle at (meshIdx, triIdx),
+// or -1 if not found. Used to seed A* from a Picker selection.
+func (g *NavGraph) TriangleAt(meshIdx, triIdx int) int {
+	for i, t := range g.triangles {
+		if t.mesh == meshIdx && t.tri == triIdx {
+			return i
+		}
+	}
+	return -1
+}
+
+// pqItem is a min-heap entry for the A* open set.
+type pqItem struct {
+	node     int
+	priority float32
+	index    int
+}
+
+type priorityQueue []*pqItem
+
+func (pq priorityQueue) Len() int           { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool { return pq[i].priority < pq[j].priority }
+func (pq priorityQueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i]; pq[i].index, pq[j].index = i, j }
+func (pq *priorityQueue) Push(x interface{}) {
+	item := x.(*pqItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+func (pq *priorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
+
+// FindPath runs A* over triangle centroids with a Euclidean-distance
+// heuristic and returns the sequence of graph node indices from start to
+// goal (inclusive), or nil if no path exists.
+func (g *NavGraph) FindPath(start, goal int) []int {
+	if start < 0 || goal < 0 || start >= len(g.triangles) || goal >= len(g.triangles) {
+		return nil
+	}
+	if start == goal {
+		return []int{start}
+	}
+
+	cameFrom := make(map[int]int)
+	gScore := make(map[int]float32)
+	gScore[start] = 0
+
+	open := &priorityQueue{}
+	heap.Init(open)
+	heap.Push(open, &pqItem{node: start, priority: g.heuristic(start, goal)})
+
+	visited := make(map[int]bool)
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*pqItem).node
+		if current == goal {
+			return g.reconstructPath(cameFrom, current)
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for _, next := range g.triangles[current].neighbors {
+			tentative := gScore[current] + g.centroidDistance(current, next)
+			if existing, ok := gScore[next]; !ok || tentative < existing {
+				cameFrom[next] = current
+				gScore[next] = tentative
+				heap.Push(open, &pqItem{node: next, priority: tentative + g.heuristic(next, goal)})
+			}
+		}
+	}
+
+	return nil
+}
+
+func (g *NavGraph) heuristic(a, b int) float32 {
+	return g.centroidDistance(a, b)
+}
+
+func (g *NavGraph) centroidDistance(a, b int) float32 {
+	return g.triangles[a].centroid.Sub(g.triangles[b].centroid).Len()
+}
+
+func (g *NavGraph) reconstructPath(cameFrom map[int]int, current int) []int {
+	path := []int{current}
+	for {
+		prev, ok := cameFrom[current]
+		if !ok {
+			break
+		}
+		path = append([]int{prev}, path...)
+		current = prev
+	}
+	return path
+}
+
+// Funnel runs Simple Stupid Funnel Algorithm (string pulling) over the
+// shared edges of a triangle corridor, producing the shortest polyline
+// from start to goal that stays within the corridor.
+func (g *NavGraph) Funnel(path []int, start, goal mgl32.Vec3) []mgl32.Vec3 {
+	if len(path) < 2 {
+		return []mgl32.Vec3{start, goal}
+	}
+
+	type portal struct{ left, right mgl32.Vec3 }
+	portals := make([]portal, 0, len(path))
+	portals = append(portals, portal{start, start})
+	for i := 0; i < len(path)-1; i++ {
+		edge := g.triangles[path[i]].sharedEdge[path[i+1]]
+		left, right := orientPortal(&g.triangles[path[i]], edge)
+		portals = append(portals, portal{left, right})
+	}
+	portals = append(portals, portal{goal, goal})
+
+	// appendVertex skips a push that would duplicate the last vertex already
+	// in result: two restarts back to back (one off the left wall, one off
+	// the right) can both land on the same corner point, and a zero-length
+	// trailing segment isn't part of a useful path.
+	appendVertex := func(result []mgl32.Vec3, v mgl32.Vec3) []mgl32.Vec3 {
+		if len(result) > 0 && result[len(result)-1].ApproxEqual(v) {
+			return result
+		}
+		return append(result, v)
+	}
+
+	result := []mgl32.Vec3{start}
+	apex, left, right := start, portals[0].left, portals[0].right
+	apexIdx, leftIdx, rightIdx := 0, 0, 0
+
+	for i := 1; i < len(portals); i++ {
+		p := portals[i]
+
+		if triArea2(apex, right, p.right) <= 0 {
+			if apex.ApproxEqual(right) || triArea2(apex, left, p.right) > 0 {
+				right = p.right
+				rightIdx = i
+			} else {
+				result = appendVertex(result, left)
+				apex, apexIdx = left, leftIdx
+				left, right = apex, apex
+				leftIdx, rightIdx = apexIdx, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+
+		if triArea2(apex, left, p.left) >= 0 {
+			if apex.ApproxEqual(left) || triArea2(apex, right, p.left) < 0 {
+				left = p.left
+				leftIdx = i
+			} else {
+				result = appendVertex(result, right)
+				apex, apexIdx = right, rightIdx
+				left, right = apex, apex
+				leftIdx, rightIdx = apexIdx, apexIdx
+				i = apexIdx
+				continue
+			}
+		}
+	}
+
+	result = appendVertex(result, goal)
+	return result
+}
+
+// triArea2 returns twice the signed area of triangle (a, b, c) projected
+// onto the XZ plane, used by the funnel algorithm to test turn direction.
+func triArea2(a, b, c mgl32.Vec3) float32 {
+	return (b.X()-a.X())*(c.Z()-a.Z()) - (c.X()-a.X())*(b.Z()-a.Z())
+}
+
+// orientPortal assigns edge's two endpoints to the funnel's left/right
+// slots using t's own vertex winding, rather than the arbitrary owner order
+// BuildNavGraph happened to insert the shared edge in. Deriving left/right
+// from each triangle's winding keeps them consistent at every portal along
+// the corridor, including around bends, since winding is a property of the
+// triangle rather than of which direction the corridor happens to snake
+// between centroids. For a triangle wound counter-clockwise seen from above
+// (+Y face normal), leaving through its edge a->b puts b on the left and a
+// on the right, which is the side convention triArea2's tests use.
+func orientPortal(t *navTriangle, edge [2]mgl32.Vec3) (left, right mgl32.Vec3) {
+	verts := [3]mgl32.Vec3{t.v0, t.v1, t.v2}
+	for i := 0; i < 3; i++ {
+		a, b := verts[i], verts[(i+1)%3]
+		if (a.ApproxEqual(edge[0]) && b.ApproxEqual(edge[1])) || (a.ApproxEqual(edge[1]) && b.ApproxEqual(edge[0])) {
+			return b, a
+		}
+	}
+	return edge[0], edge[1]
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const lineVertexShaderSource = `
+    #version 410
+    layout (location = 0) in vec3 position;
+
+    uniform mat4 projection;
+    uniform mat4 camera;
+
+    void main() {
+        gl_Position = projection * camera * vec4(position, 1.0);
+    }
+    ` + "\x00"
+
+const lineFragmentShaderSource = `
+    #version 410
+    uniform vec4 lineColor;
+    out vec4 color;
+
+    void main() {
+        color = lineColor;
+    }
+    ` + "\x00"
+
+// PathRenderer draws the A* corridor (translucent triangles) and the
+// funnel-smoothed path (a line strip) using a small dedicated program,
+// separate from the main lit/wireframe shader. The strip is drawn with
+// gl.LineWidth(4.0); in the forward-compatible core context initializeWindow
+// creates, GL rejects widths above 1.0, so there the path is 1px wide.
+type PathRenderer struct {
+	program uint32
+
+	corridorVAO, corridorVBO uint32
+	corridorVerts            int32
+
+	pathVAO, pathVBO uint32
+	pathVerts        int32
+}
+
+// NewPathRenderer links the line-shader program and allocates the dynamic
+// vertex buffers used for the corridor fill and the path line strip.
+func NewPathRenderer() *PathRenderer {
+	r := &PathRenderer{program: linkProgram(lineVertexShaderSource, lineFragmentShaderSource)}
+
+	gl.GenVertexArrays(1, &r.corridorVAO)
+	gl.GenBuffers(1, &r.corridorVBO)
+	gl.BindVertexArray(r.corridorVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.corridorVBO)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, nil)
+
+	gl.GenVertexArrays(1, &r.pathVAO)
+	gl.GenBuffers(1, &r.pathVBO)
+	gl.BindVertexArray(r.pathVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.pathVBO)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, nil)
+
+	return r
+}
+
+// Destroy releases the GL objects owned by the renderer.
+func (r *PathRenderer) Destroy() {
+	gl.DeleteVertexArrays(1, &r.corridorVAO)
+	gl.DeleteBuffers(1, &r.corridorVBO)
+	gl.DeleteVertexArrays(1, &r.pathVAO)
+	gl.DeleteBuffers(1, &r.pathVBO)
+	gl.DeleteProgram(r.program)
+}
+
+// SetCorridor uploads the triangles of the A* corridor for translucent
+// shading, lifted slightly above the navmesh to avoid z-fighting.
+func (r *PathRenderer) SetCorridor(triangles []navTriangle) {
+	verts := make([]float32, 0, len(triangles)*9)
+	for _, t := range triangles {
+		for _, v := range [3]mgl32.Vec3{t.v0, t.v1, t.v2} {
+			lifted := v.Add(mgl32.Vec3{0, 0.5, 0})
+			verts = append(verts, lifted.X(), lifted.Y(), lifted.Z())
+		}
+	}
+	r.corridorVerts = int32(len(triangles) * 3)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.corridorVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(verts)*4, gl.Ptr(verts), gl.DYNAMIC_DRAW)
+}
+
+// SetPath uploads the funnel-smoothed polyline to draw as a line strip.
+func (r *PathRenderer) SetPath(points []mgl32.Vec3) {
+	verts := make([]float32, 0, len(points)*3)
+	for _, p := range points {
+		lifted := p.Add(mgl32.Vec3{0, 1.0, 0})
+		verts = append(verts, lifted.X(), lifted.Y(), lifted.Z())
+	}
+	r.pathVerts = int32(len(points))
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.pathVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(verts)*4, gl.Ptr(verts), gl.DYNAMIC_DRAW)
+}
+
+// Clear drops any uploaded corridor/path so Draw becomes a no-op.
+func (r *PathRenderer) Clear() {
+	r.corridorVerts = 0
+	r.pathVerts = 0
+}
+
+// Draw renders the corridor fill (if any) followed by the path line strip
+// (if any), using the given frame's projection/view matrices.
+func (r *PathRenderer) Draw(projection, view mgl32.Mat4) {
+	if r.corridorVerts == 0 && r.pathVerts == 0 {
+		return
+	}
+
+	gl.UseProgram(r.program)
+	gl.UniformMatrix4fv(gl.GetUniformLocation(r.program, gl.Str("projection\x00")), 1, false, &projection[0])
+	gl.UniformMatrix4fv(gl.GetUniformLocation(r.program, gl.Str("camera\x00")), 1, false, &view[0])
+
+	if r.corridorVerts > 0 {
+		gl.Enable(gl.BLEND)
+		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
+		gl.Disable(gl.CULL_FACE)
+		gl.Uniform4f(gl.GetUniformLocation(r.program, gl.Str("lineColor\x00")), 0.2, 0.8, 0.2, 0.35)
+		gl.BindVertexArray(r.corridorVAO)
+		gl.DrawArrays(gl.TRIANGLES, 0, r.corridorVerts)
+		gl.Enable(gl.CULL_FACE)
+		gl.Disable(gl.BLEND)
+	}
+
+	if r.pathVerts > 0 {
+		gl.LineWidth(4.0)
+		gl.Uniform4f(gl.GetUniformLocation(r.program, gl.Str("lineColor\x00")), 1.0, 1.0, 0.0, 1.0)
+		gl.BindVertexArray(r.pathVAO)
+		gl.DrawArrays(gl.LINE_STRIP, 0, r.pathVerts)
+		gl.LineWidth(1.0)
+	}
+}
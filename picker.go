@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Selection describes the triangle a Picker last hit, along with the data
+// needed to highlight it and print its info panel.
+type Selection struct {
+	MeshIndex  int
+	TriIndex   int
+	Filename   string
+	V0, V1, V2 mgl32.Vec3
+	Normal     mgl32.Vec3
+	Area       float32
+	Distance   float32
+}
+
+// Picker owns ray construction against the current camera/projection,
+// linear ray-triangle intersection against every loaded mesh, and the
+// currently selected triangle (if any). Future work can swap the linear
+// scan for a BVH and add multi-select on top of this type without
+// touching callers.
+type Picker struct {
+	highlightVAO uint32
+	highlightVBO uint32
+
+	Selected *Selection
+}
+
+// NewPicker allocates the GL objects used to draw the highlight triangle.
+func NewPicker() *Picker {
+	p := &Picker{}
+
+	gl.GenVertexArrays(1, &p.highlightVAO)
+	gl.GenBuffers(1, &p.highlightVBO)
+	gl.BindVertexArray(p.highlightVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, p.highlightVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, 3*3*4, nil, gl.DYNAMIC_DRAW)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 0, nil)
+
+	return p
+}
+
+// Destroy releases the highlight GL objects.
+func (p *Picker) Destroy() {
+	gl.DeleteVertexArrays(1, &p.highlightVAO)
+	gl.DeleteBuffers(1, &p.highlightVBO)
+}
+
+// rayFromCursor unprojects the window-space cursor position into a
+// world-space ray using the inverse of projection*view. crosshair picks the
+// screen center instead of the cursor position, for camera modes that lock
+// the cursor (see Pick).
+func rayFromCursor(window *glfw.Window, projection, view mgl32.Mat4, crosshair bool) (origin, dir mgl32.Vec3) {
+	var ndcX, ndcY float32
+	if crosshair {
+		ndcX, ndcY = 0, 0
+	} else {
+		w, h := window.GetSize()
+		x, y := window.GetCursorPos()
+
+		// Normalized device coordinates, Y flipped since cursor Y grows downward.
+		ndcX = float32(2*x/float64(w) - 1)
+		ndcY = float32(1 - 2*y/float64(h))
+	}
+
+	inv := projection.Mul4(view).Inv()
+
+	nearPoint := inv.Mul4x1(mgl32.Vec4{ndcX, ndcY, -1, 1})
+	farPoint := inv.Mul4x1(mgl32.Vec4{ndcX, ndcY, 1, 1})
+
+	near := mgl32.Vec3{nearPoint.X() / nearPoint.W(), nearPoint.Y() / nearPoint.W(), nearPoint.Z() / nearPoint.W()}
+	far := mgl32.Vec3{farPoint.X() / farPoint.W(), farPoint.Y() / farPoint.W(), farPoint.Z() / farPoint.W()}
+
+	origin = near
+	dir = far.Sub(near).Normalize()
+	return origin, dir
+}
+
+// intersectTriangle implements the Möller–Trumbore ray-triangle
+// intersection test. It returns the hit distance along dir and true if the
+// ray hits the triangle in front of the origin.
+func intersectTriangle(origin, dir, v0, v1, v2 mgl32.Vec3) (float32, bool) {
+	const epsilon = 1e-7
+
+	edge1 := v1.Sub(v0)
+	edge2 := v2.Sub(v0)
+	h := dir.Cross(edge2)
+	a := edge1.Dot(h)
+	if a > -epsilon && a < epsilon {
+		return 0, false // ray parallel to triangle
+	}
+
+	f := 1.0 / a
+	s := origin.Sub(v0)
+	u := f * s.Dot(h)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+
+	q := s.Cross(edge1)
+	v := f * dir.Dot(q)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+
+	t := f * edge2.Dot(q)
+	if t <= epsilon {
+		return 0, false
+	}
+
+	return t, true
+}
+
+// Pick casts a ray from the cursor and keeps the nearest triangle hit
+// across every mesh in the scene. It returns the selection (also stored on
+// the Picker) or nil if nothing was hit. crosshair must be true when the
+// active camera is FPSCamera: the window is in glfw.CursorDisabled there,
+// where GetCursorPos is an unbounded virtual accumulator rather than a
+// window-relative coordinate, so the cast has to come from the crosshair
+// at screen center instead.
+func (p *Picker) Pick(window *glfw.Window, scene Scene, projection, view mgl32.Mat4, crosshair bool) *Selection {
+	origin, dir := rayFromCursor(window, projection, view, crosshair)
+
+	var best *Selection
+	var bestDist float32 = float32(math.Inf(1))
+
+	for meshIdx, mesh := range scene.meshes {
+		for tri := 0; tri+2 < len(mesh.indices); tri += 3 {
+			v0 := vertexAt(mesh.vertices, mesh.indices[tri])
+			v1 := vertexAt(mesh.vertices, mesh.indices[tri+1])
+			v2 := vertexAt(mesh.vertices, mesh.indices[tri+2])
+
+			dist, hit := intersectTriangle(origin, dir, v0, v1, v2)
+			if !hit || dist >= bestDist {
+				continue
+			}
+
+			normal := v1.Sub(v0).Cross(v2.Sub(v0))
+			area := normal.Len() / 2
+			filename := ""
+			if meshIdx < len(scene.filenames) {
+				filename = scene.filenames[meshIdx]
+			}
+
+			bestDist = dist
+			best = &Selection{
+				MeshIndex: meshIdx,
+				TriIndex:  tri / 3,
+				Filename:  filename,
+				V0:        v0,
+				V1:        v1,
+				V2:        v2,
+				Normal:    normal.Normalize(),
+				Area:      area,
+				Distance:  dist,
+			}
+		}
+	}
+
+	p.Selected = best
+	if best != nil {
+		p.uploadHighlight(*best)
+		log.Print(best.InfoPanel())
+	}
+	return best
+}
+
+func vertexAt(vertices []float32, index uint32) mgl32.Vec3 {
+	i := index * 3
+	return mgl32.Vec3{vertices[i], vertices[i+1], vertices[i+2]}
+}
+
+func (p *Picker) uploadHighlight(sel Selection) {
+	data := []float32{
+		sel.V0.X(), sel.V0.Y(), sel.V0.Z(),
+		sel.V1.X(), sel.V1.Y(), sel.V1.Z(),
+		sel.V2.X(), sel.V2.Y(), sel.V2.Z(),
+	}
+	gl.BindBuffer(gl.ARRAY_BUFFER, p.highlightVBO)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(data)*4, gl.Ptr(data))
+}
+
+// DrawHighlight re-draws the selected triangle in a second color on top of
+// the already-rendered scene. program must already be in use with the
+// camera/projection/model uniforms set for this frame.
+func (p *Picker) DrawHighlight(program uint32) {
+	if p.Selected == nil {
+		return
+	}
+
+	highlightUniform := gl.GetUniformLocation(program, gl.Str("isWireframe\x00"))
+	gl.Uniform1i(highlightUniform, 2) // 2 selects the highlight color in the fragment shader
+
+	gl.Disable(gl.DEPTH_TEST)
+	gl.BindVertexArray(p.highlightVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// InfoPanel formats the selection as a single log line: mesh filename,
+// triangle index, vertex positions, face normal, and area. HUD.Draw shows
+// the same fields broken across several shorter on-screen lines instead of
+// reusing this string, since it's wider than the window.
+func (s *Selection) InfoPanel() string {
+	return fmt.Sprintf(
+		"Selected %s tri #%d | v0=(%.2f, %.2f, %.2f) v1=(%.2f, %.2f, %.2f) v2=(%.2f, %.2f, %.2f) | normal=(%.2f, %.2f, %.2f) area=%.3f",
+		s.Filename, s.TriIndex,
+		s.V0.X(), s.V0.Y(), s.V0.Z(),
+		s.V1.X(), s.V1.Y(), s.V1.Z(),
+		s.V2.X(), s.V2.Y(), s.V2.Z(),
+		s.Normal.X(), s.Normal.Y(), s.Normal.Z(),
+		s.Area,
+	)
+}
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// PostProcessMode selects what happens between the offscreen MSAA target and
+// the default framebuffer.
+type PostProcessMode int
+
+const (
+	PostProcessOff PostProcessMode = iota
+	PostProcessFXAA
+	PostProcessMSAAOnly
+)
+
+func (m PostProcessMode) String() string {
+	switch m {
+	case PostProcessFXAA:
+		return "FXAA"
+	case PostProcessMSAAOnly:
+		return "MSAA"
+	default:
+		return "Off"
+	}
+}
+
+// PostProcessor owns the offscreen multisample framebuffer the scene is
+// rendered into, the single-sample resolve target it is blitted to, and the
+// full-screen quad pipeline used to run FXAA over the resolved color.
+type PostProcessor struct {
+	width, height int32
+	samples       int32
+
+	msaaFBO      uint32
+	msaaColorRBO uint32
+	msaaDepthRBO uint32
+
+	resolveFBO uint32
+	resolveTex uint32
+
+	quadVAO uint32
+	quadVBO uint32
+	program uint32
+
+	EdgeThreshold    float32
+	EdgeThresholdMin float32
+	SubpixelQuality  float32
+}
+
+var fullscreenQuadVertices = []float32{
+	// positions   // uv
+	-1, -1, 0, 0,
+	1, -1, 1, 0,
+	1, 1, 1, 1,
+	-1, -1, 0, 0,
+	1, 1, 1, 1,
+	-1, 1, 0, 1,
+}
+
+const fxaaVertexShaderSource = `
+    #version 410
+    layout (location = 0) in vec2 position;
+    layout (location = 1) in vec2 uv;
+
+    out vec2 TexCoord;
+
+    void main() {
+        TexCoord = uv;
+        gl_Position = vec4(position, 0.0, 1.0);
+    }
+    ` + "\x00"
+
+// FXAA port: luma is computed from the resolved RGB color (a weighted sum,
+// so this works without an alpha channel), edges are detected from the
+// NW/NE/SW/SE/center taps, and up to eight samples are taken toward each
+// end of the detected edge.
+const fxaaFragmentShaderSource = `
+    #version 410
+    in vec2 TexCoord;
+    out vec4 color;
+
+    uniform sampler2D screenTexture;
+    uniform vec2 texelSize;
+    uniform float edgeThreshold;
+    uniform float edgeThresholdMin;
+    uniform float subpixelQuality;
+
+    float luma(vec3 rgb) {
+        return dot(rgb, vec3(0.299, 0.587, 0.114));
+    }
+
+    void main() {
+        vec3 colorCenter = texture(screenTexture, TexCoord).rgb;
+
+        float lumaCenter = luma(colorCenter);
+        float lumaDown  = luma(textureOffset(screenTexture, TexCoord, ivec2(0, -1)).rgb);
+        float lumaUp    = luma(textureOffset(screenTexture, TexCoord, ivec2(0, 1)).rgb);
+        float lumaLeft  = luma(textureOffset(screenTexture, TexCoord, ivec2(-1, 0)).rgb);
+        float lumaRight = luma(textureOffset(screenTexture, TexCoord, ivec2(1, 0)).rgb);
+        float lumaDownLeft  = luma(textureOffset(screenTexture, TexCoord, ivec2(-1, -1)).rgb);
+        float lumaUpRight   = luma(textureOffset(screenTexture, TexCoord, ivec2(1, 1)).rgb);
+        float lumaUpLeft    = luma(textureOffset(screenTexture, TexCoord, ivec2(-1, 1)).rgb);
+        float lumaDownRight = luma(textureOffset(screenTexture, TexCoord, ivec2(1, -1)).rgb);
+
+        float lumaMin = min(lumaCenter, min(min(lumaDownLeft, lumaUpRight), min(lumaUpLeft, lumaDownRight)));
+        float lumaMax = max(lumaCenter, max(max(lumaDownLeft, lumaUpRight), max(lumaUpLeft, lumaDownRight)));
+        float lumaRange = lumaMax - lumaMin;
+
+        if (lumaRange < max(edgeThresholdMin, lumaMax * edgeThreshold)) {
+            color = vec4(colorCenter, 1.0);
+            return;
+        }
+
+        float lumaDownUp = lumaDown + lumaUp;
+        float lumaLeftRight = lumaLeft + lumaRight;
+
+        float lumaLeftCorners = lumaDownLeft + lumaUpLeft;
+        float lumaDownCorners = lumaDownLeft + lumaDownRight;
+        float lumaRightCorners = lumaDownRight + lumaUpRight;
+        float lumaUpCorners = lumaUpRight + lumaUpLeft;
+
+        float edgeHorizontal = abs(-2.0 * lumaLeft + lumaLeftCorners) + abs(-2.0 * lumaCenter + lumaDownUp) * 2.0 + abs(-2.0 * lumaRight + lumaRightCorners);
+        float edgeVertical = abs(-2.0 * lumaUp + lumaUpCorners) + abs(-2.0 * lumaCenter + lumaLeftRight) * 2.0 + abs(-2.0 * lumaDown + lumaDownCorners);
+        bool isHorizontal = edgeHorizontal >= edgeVertical;
+
+        float luma1 = isHorizontal ? lumaDown : lumaLeft;
+        float luma2 = isHorizontal ? lumaUp : lumaRight;
+        float gradient1 = luma1 - lumaCenter;
+        float gradient2 = luma2 - lumaCenter;
+        bool is1Steepest = abs(gradient1) >= abs(gradient2);
+        float gradientScaled = 0.25 * max(abs(gradient1), abs(gradient2));
+
+        float stepLength = isHorizontal ? texelSize.y : texelSize.x;
+        float lumaLocalAverage = 0.0;
+        if (is1Steepest) {
+            stepLength = -stepLength;
+            lumaLocalAverage = 0.5 * (luma1 + lumaCenter);
+        } else {
+            lumaLocalAverage = 0.5 * (luma2 + lumaCenter);
+        }
+
+        vec2 currentUv = TexCoord;
+        if (isHorizontal) {
+            currentUv.y += stepLength * 0.5;
+        } else {
+            currentUv.x += stepLength * 0.5;
+        }
+
+        vec2 offset = isHorizontal ? vec2(texelSize.x, 0.0) : vec2(0.0, texelSize.y);
+        vec2 uv1 = currentUv - offset;
+        vec2 uv2 = currentUv + offset;
+
+        float lumaEnd1 = luma(texture(screenTexture, uv1).rgb) - lumaLocalAverage;
+        float lumaEnd2 = luma(texture(screenTexture, uv2).rgb) - lumaLocalAverage;
+        bool reached1 = abs(lumaEnd1) >= gradientScaled;
+        bool reached2 = abs(lumaEnd2) >= gradientScaled;
+        bool reachedBoth = reached1 && reached2;
+
+        if (!reached1) { uv1 -= offset; }
+        if (!reached2) { uv2 += offset; }
+
+        for (int i = 2; i <= 8 && !reachedBoth; i++) {
+            if (!reached1) {
+                lumaEnd1 = luma(texture(screenTexture, uv1).rgb) - lumaLocalAverage;
+                reached1 = abs(lumaEnd1) >= gradientScaled;
+                if (!reached1) { uv1 -= offset; }
+            }
+            if (!reached2) {
+                lumaEnd2 = luma(texture(screenTexture, uv2).rgb) - lumaLocalAverage;
+                reached2 = abs(lumaEnd2) >= gradientScaled;
+                if (!reached2) { uv2 += offset; }
+            }
+            reachedBoth = reached1 && reached2;
+        }
+
+        float distance1 = isHorizontal ? (TexCoord.x - uv1.x) : (TexCoord.y - uv1.y);
+        float distance2 = isHorizontal ? (uv2.x - TexCoord.x) : (uv2.y - TexCoord.y);
+
+        bool isDirection1 = distance1 < distance2;
+        float distanceFinal = min(distance1, distance2);
+        float edgeThicknessTotal = distance1 + distance2;
+        float pixelOffset = -distanceFinal / edgeThicknessTotal + 0.5;
+
+        bool isLumaCenterSmaller = lumaCenter < lumaLocalAverage;
+        bool correctVariation = ((isDirection1 ? lumaEnd1 : lumaEnd2) < 0.0) != isLumaCenterSmaller;
+        float finalOffset = correctVariation ? pixelOffset : 0.0;
+
+        float lumaAverage = (1.0 / 12.0) * (2.0 * (lumaDownUp + lumaLeftRight) + lumaLeftCorners + lumaRightCorners);
+        float subPixelOffset1 = clamp(abs(lumaAverage - lumaCenter) / lumaRange, 0.0, 1.0);
+        float subPixelOffset2 = (-2.0 * subPixelOffset1 + 3.0) * subPixelOffset1 * subPixelOffset1;
+        float subPixelOffsetFinal = subPixelOffset2 * subPixelOffset2 * subpixelQuality;
+
+        finalOffset = max(finalOffset, subPixelOffsetFinal);
+
+        vec2 finalUv = TexCoord;
+        if (isHorizontal) {
+            finalUv.y += finalOffset * stepLength;
+        } else {
+            finalUv.x += finalOffset * stepLength;
+        }
+
+        color = vec4(texture(screenTexture, finalUv).rgb, 1.0);
+    }
+    ` + "\x00"
+
+// NewPostProcessor allocates the offscreen MSAA target, the single-sample
+// resolve target, and the FXAA quad program for a framebuffer of the given
+// size. samples must match (or be below) the GL_SAMPLES the context was
+// created with.
+func NewPostProcessor(width, height, samples int32) *PostProcessor {
+	p := &PostProcessor{
+		width:            width,
+		height:           height,
+		samples:          samples,
+		EdgeThreshold:    0.166,
+		EdgeThresholdMin: 0.0833,
+		SubpixelQuality:  0.75,
+	}
+
+	p.program = linkProgram(fxaaVertexShaderSource, fxaaFragmentShaderSource)
+
+	gl.GenVertexArrays(1, &p.quadVAO)
+	gl.GenBuffers(1, &p.quadVBO)
+	gl.BindVertexArray(p.quadVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, p.quadVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(fullscreenQuadVertices)*4, gl.Ptr(fullscreenQuadVertices), gl.STATIC_DRAW)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointer(0, 2, gl.FLOAT, false, 4*4, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(1)
+	gl.VertexAttribPointer(1, 2, gl.FLOAT, false, 4*4, gl.PtrOffset(2*4))
+
+	p.allocateTargets()
+
+	return p
+}
+
+func (p *PostProcessor) allocateTargets() {
+	gl.GenFramebuffers(1, &p.msaaFBO)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.msaaFBO)
+
+	gl.GenRenderbuffers(1, &p.msaaColorRBO)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, p.msaaColorRBO)
+	gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, p.samples, gl.RGBA8, p.width, p.height)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.RENDERBUFFER, p.msaaColorRBO)
+
+	gl.GenRenderbuffers(1, &p.msaaDepthRBO)
+	gl.BindRenderbuffer(gl.RENDERBUFFER, p.msaaDepthRBO)
+	gl.RenderbufferStorageMultisample(gl.RENDERBUFFER, p.samples, gl.DEPTH24_STENCIL8, p.width, p.height)
+	gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, p.msaaDepthRBO)
+
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		fmt.Fprintf(os.Stderr, "post-process: msaa framebuffer incomplete: 0x%x\n", status)
+	}
+
+	gl.GenFramebuffers(1, &p.resolveFBO)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.resolveFBO)
+
+	gl.GenTextures(1, &p.resolveTex)
+	gl.BindTexture(gl.TEXTURE_2D, p.resolveTex)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA8, p.width, p.height, 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, p.resolveTex, 0)
+
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		fmt.Fprintf(os.Stderr, "post-process: resolve framebuffer incomplete: 0x%x\n", status)
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+func (p *PostProcessor) releaseTargets() {
+	gl.DeleteFramebuffers(1, &p.msaaFBO)
+	gl.DeleteRenderbuffers(1, &p.msaaColorRBO)
+	gl.DeleteRenderbuffers(1, &p.msaaDepthRBO)
+	gl.DeleteFramebuffers(1, &p.resolveFBO)
+	gl.DeleteTextures(1, &p.resolveTex)
+}
+
+// Resize tears down and recreates the offscreen targets for a new
+// framebuffer size.
+func (p *PostProcessor) Resize(width, height int32) {
+	if width == p.width && height == p.height {
+		return
+	}
+	p.releaseTargets()
+	p.width, p.height = width, height
+	p.allocateTargets()
+}
+
+// Begin binds the render target scene draws land in for mode: the default
+// framebuffer directly for Off, so a true no-AA baseline is possible, or the
+// offscreen multisample framebuffer for MSAAOnly/FXAA.
+func (p *PostProcessor) Begin(mode PostProcessMode) {
+	if mode == PostProcessOff {
+		// The window itself was created with a multisampled default
+		// framebuffer (see glfw.Samples in initializeWindow), so drawing
+		// straight into it isn't a true no-AA baseline unless multisample
+		// rasterization is also switched off for the frame.
+		gl.Disable(gl.MULTISAMPLE)
+		gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+		gl.Viewport(0, 0, p.width, p.height)
+		return
+	}
+	gl.Enable(gl.MULTISAMPLE)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, p.msaaFBO)
+	gl.Viewport(0, 0, p.width, p.height)
+}
+
+// Composite resolves the multisample target and, depending on mode, either
+// blits it straight to the default framebuffer (MSAAOnly) or runs the FXAA
+// pass over it (FXAA). Off already rendered straight to the default
+// framebuffer in Begin, so there's nothing left to composite.
+func (p *PostProcessor) Composite(mode PostProcessMode) {
+	if mode == PostProcessOff {
+		return
+	}
+
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, p.msaaFBO)
+	gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, p.resolveFBO)
+	gl.BlitFramebuffer(0, 0, p.width, p.height, 0, 0, p.width, p.height, gl.COLOR_BUFFER_BIT, gl.NEAREST)
+
+	if mode != PostProcessFXAA {
+		gl.BindFramebuffer(gl.READ_FRAMEBUFFER, p.resolveFBO)
+		gl.BindFramebuffer(gl.DRAW_FRAMEBUFFER, 0)
+		gl.BlitFramebuffer(0, 0, p.width, p.height, 0, 0, p.width, p.height, gl.COLOR_BUFFER_BIT, gl.NEAREST)
+		return
+	}
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+	gl.Disable(gl.DEPTH_TEST)
+	gl.UseProgram(p.program)
+
+	gl.ActiveTexture(gl.TEXTURE0)
+	gl.BindTexture(gl.TEXTURE_2D, p.resolveTex)
+	gl.Uniform1i(gl.GetUniformLocation(p.program, gl.Str("screenTexture\x00")), 0)
+	gl.Uniform2f(gl.GetUniformLocation(p.program, gl.Str("texelSize\x00")), 1.0/float32(p.width), 1.0/float32(p.height))
+	gl.Uniform1f(gl.GetUniformLocation(p.program, gl.Str("edgeThreshold\x00")), p.EdgeThreshold)
+	gl.Uniform1f(gl.GetUniformLocation(p.program, gl.Str("edgeThresholdMin\x00")), p.EdgeThresholdMin)
+	gl.Uniform1f(gl.GetUniformLocation(p.program, gl.Str("subpixelQuality\x00")), p.SubpixelQuality)
+
+	gl.BindVertexArray(p.quadVAO)
+	gl.DrawArrays(gl.TRIANGLES, 0, 6)
+
+	gl.Enable(gl.DEPTH_TEST)
+}
+
+// Destroy releases all GL objects owned by the post-processor.
+func (p *PostProcessor) Destroy() {
+	p.releaseTargets()
+	gl.DeleteVertexArrays(1, &p.quadVAO)
+	gl.DeleteBuffers(1, &p.quadVBO)
+	gl.DeleteProgram(p.program)
+}
+
+// linkProgram compiles and links a vertex/fragment shader pair, panicking
+// with the link log on failure (mirrors initializeShaders' error handling).
+func linkProgram(vertexSource, fragmentSource string) uint32 {
+	program := gl.CreateProgram()
+
+	vertexShader := compileShader(vertexSource, gl.VERTEX_SHADER)
+	fragmentShader := compileShader(fragmentSource, gl.FRAGMENT_SHADER)
+
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+		programLog := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(programLog))
+		panic(fmt.Errorf("failed to link program: %v", programLog))
+	}
+
+	gl.DeleteShader(vertexShader)
+	gl.DeleteShader(fragmentShader)
+
+	return program
+}
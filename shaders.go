@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+const (
+	shaderDir          = "shaders"
+	vertexShaderPath   = "mesh.vert"
+	fragmentShaderPath = "mesh.frag"
+)
+
+// MaterialMode selects the mesh.frag lighting/coloring branch renderScene's
+// "material" uniform picks between.
+type MaterialMode int
+
+const (
+	MaterialFlat MaterialMode = iota
+	MaterialNormal
+	MaterialHeightRamp
+	MaterialAreaHash
+)
+
+func (m MaterialMode) String() string {
+	switch m {
+	case MaterialNormal:
+		return "NORMAL"
+	case MaterialHeightRamp:
+		return "HEIGHT"
+	case MaterialAreaHash:
+		return "AREA"
+	default:
+		return "FLAT"
+	}
+}
+
+func cycleMaterialMode() {
+	materialMode = (materialMode + 1) % 4
+}
+
+// readShaderSource loads a shader file from shaders/ and appends the
+// trailing NUL gl.Str needs, matching the embedded sources it replaces.
+func readShaderSource(name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(shaderDir, name))
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\x00", nil
+}
+
+// compileShaderSafe is compileShader without the log.Fatal: reloadShaders
+// needs to report a bad shader and keep the program that's already running.
+func compileShaderSafe(source string, shaderType uint32) (uint32, error) {
+	shader := gl.CreateShader(shaderType)
+	csources, free := gl.Strs(source)
+	gl.ShaderSource(shader, 1, csources, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
+		logText := strings.Repeat("\x00", int(logLength+1))
+		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(logText))
+		gl.DeleteShader(shader)
+		return 0, fmt.Errorf("compile failed: %s", logText)
+	}
+	return shader, nil
+}
+
+// linkProgramSafe mirrors initializeShaders' link step but returns an error
+// instead of panicking, so a bad on-disk edit can't take the viewer down.
+func linkProgramSafe(vertexSource, fragmentSource string) (uint32, error) {
+	vertexShader, err := compileShaderSafe(vertexSource, gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, fmt.Errorf("vertex shader: %w", err)
+	}
+	fragmentShader, err := compileShaderSafe(fragmentSource, gl.FRAGMENT_SHADER)
+	if err != nil {
+		gl.DeleteShader(vertexShader)
+		return 0, fmt.Errorf("fragment shader: %w", err)
+	}
+
+	program := gl.CreateProgram()
+	gl.AttachShader(program, vertexShader)
+	gl.AttachShader(program, fragmentShader)
+	gl.LinkProgram(program)
+
+	var status int32
+	gl.GetProgramiv(program, gl.LINK_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetProgramiv(program, gl.INFO_LOG_LENGTH, &logLength)
+		logText := strings.Repeat("\x00", int(logLength+1))
+		gl.GetProgramInfoLog(program, logLength, nil, gl.Str(logText))
+		gl.DeleteShader(vertexShader)
+		gl.DeleteShader(fragmentShader)
+		gl.DeleteProgram(program)
+		return 0, fmt.Errorf("link failed: %s", logText)
+	}
+
+	gl.DeleteShader(vertexShader)
+	gl.DeleteShader(fragmentShader)
+	return program, nil
+}
+
+// reloadShaders re-reads mesh.vert/mesh.frag from disk and swaps glProgram
+// to a freshly linked program. On any read/compile/link failure it logs the
+// problem to stderr and leaves the currently running program untouched.
+func reloadShaders(current uint32) uint32 {
+	vertexSource, err := readShaderSource(vertexShaderPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "shader reload: %v\n", err)
+		return current
+	}
+	fragmentSource, err := readShaderSource(fragmentShaderPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "shader reload: %v\n", err)
+		return current
+	}
+
+	program, err := linkProgramSafe(vertexSource, fragmentSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "shader reload: %v\n", err)
+		return current
+	}
+
+	gl.DeleteProgram(current)
+	fmt.Fprintln(os.Stderr, "shader reload: ok")
+	return program
+}